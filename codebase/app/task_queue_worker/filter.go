@@ -0,0 +1,15 @@
+package taskqueueworker
+
+// Filter narrows StreamAllJob/persistent.UpdateJob down to a subset of jobs. Only the fields
+// this package itself reads or writes are declared here; the dashboard's full query surface
+// (date range, search text, pagination cursor, ...) lives alongside the GraphQL schema.
+type Filter struct {
+	Page, Limit  int
+	TaskNameList []string
+	Statuses     []string
+	JobID        *string
+
+	// TraceID filters jobs down to the single job attempt that produced it, so the dashboard
+	// can jump from a trace id (Job.TraceID()) back to the job that emitted it.
+	TraceID *string
+}
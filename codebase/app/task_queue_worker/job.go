@@ -0,0 +1,34 @@
+package taskqueueworker
+
+import (
+	"github.com/golangid/candi/tracer"
+)
+
+// Job represents a single task queue job attempt. Only the fields this package itself reads
+// or writes are declared here; the full persisted schema (arguments, timestamps, logs, retry
+// history, ...) lives in whatever Persistent implementation backs a given service.
+type Job struct {
+	ID       string
+	TaskName string
+	Status   string
+	Retries  int
+
+	// TraceHeader carries the W3C traceparent/baggage of the span that enqueued this job, set
+	// once by stampJobTraceHeader, so startJobSpan can resume tracing as a child of the
+	// producer instead of starting a disconnected root span.
+	TraceHeader map[string]string
+}
+
+// TraceID returns the trace id of this job's enqueue/execution trace, empty if it was never
+// stamped with a TraceHeader. Exposed to the dashboard alongside TraceURL so an operator can
+// jump from a job attempt straight to its trace. Decodes the id straight out of TraceHeader
+// instead of starting a span, so reading it is a pure getter, not I/O against the tracer
+// backend.
+func (j *Job) TraceID() string {
+	return tracer.DecodeTraceID(j.TraceHeader)
+}
+
+// TraceURL returns the tracer dashboard url for TraceID, empty under the same conditions.
+func (j *Job) TraceURL() string {
+	return tracer.BuildTraceURL(j.TraceID())
+}
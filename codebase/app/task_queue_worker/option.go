@@ -27,7 +27,8 @@ type (
 	OptionFunc func(*option)
 )
 
-// SetQueue option func
+// SetQueue option func, accepts alternative QueueStorage backends (e.g. NewJetStreamQueueStorage)
+// in addition to the default in-memory/Redis-backed queue
 func SetQueue(q QueueStorage) OptionFunc {
 	return func(o *option) {
 		o.queue = q
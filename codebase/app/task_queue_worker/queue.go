@@ -0,0 +1,40 @@
+package taskqueueworker
+
+import "context"
+
+// QueueStorage abstracts the backing store used to hand jobs off to the worker loop. Swap
+// the default in-memory/Redis-backed implementation for alternatives such as
+// NewJetStreamQueueStorage via SetQueue, so multiple candi instances can share the same
+// durable queue instead of each keeping jobs purely in their own process memory.
+type QueueStorage interface {
+	PushJob(ctx context.Context, job *Job)
+	PopJob(ctx context.Context, taskName string) (job *Job, ok bool)
+	Clear(ctx context.Context, taskName string)
+}
+
+// DurableQueueStorage is implemented by QueueStorage backends that persist jobs themselves
+// (NATS JetStream, Kafka, ...). prepare() skips Clear for these and resumes from the
+// broker's stored offset instead, so restarting a worker instance never drops in-flight jobs;
+// delivery stays at-least-once, so a job interrupted by the restart may run again.
+type DurableQueueStorage interface {
+	QueueStorage
+	IsDurable() bool
+}
+
+func isDurableQueue(q QueueStorage) bool {
+	durable, ok := unwrapQueue(q).(DurableQueueStorage)
+	return ok && durable.IsDurable()
+}
+
+// unwrapQueue sees past tracingQueueStorage (and any other future decorator that exposes the
+// same Unwrap convention) down to the backend actually configured via SetQueue, so type
+// assertions for optional capabilities (DurableQueueStorage, Subscribe) still see it.
+func unwrapQueue(q QueueStorage) QueueStorage {
+	for {
+		wrapper, ok := q.(interface{ Unwrap() QueueStorage })
+		if !ok {
+			return q
+		}
+		q = wrapper.Unwrap()
+	}
+}
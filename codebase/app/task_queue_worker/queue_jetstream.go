@@ -0,0 +1,242 @@
+package taskqueueworker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/golangid/candi/logger"
+	"github.com/golangid/candi/tracer"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+const (
+	jetStreamStreamName    = "CANDI_TASK_QUEUE"
+	jetStreamMaxDeliver    = 5
+	jetStreamConsumerDelay = 30 * time.Second
+	jetStreamStatusPoll    = 500 * time.Millisecond
+)
+
+// JetStreamQueueStorage is a QueueStorage backend on NATS JetStream, giving at-least-once
+// delivery with durable consumers so multiple candi instances can horizontally scale the
+// same task without duplicating work (coordinate exclusive processing across instances with
+// SetLocker). Each task gets its own subject and durable consumer; a message is never nacked,
+// so it is redelivered only when its ack-wait lapses without an ack, and a message that
+// reaches its jetStreamMaxDeliver-th delivery is moved to statusFailure in Persistent as a
+// dead letter instead of being run again.
+type JetStreamQueueStorage struct {
+	nc     *nats.Conn
+	js     jetstream.JetStream
+	stream jetstream.Stream
+
+	mu       sync.Mutex
+	consumes []jetstream.ConsumeContext
+}
+
+// NewJetStreamQueueStorage connects to a NATS server and ensures the candi task queue
+// stream exists, returning a QueueStorage ready to be passed to SetQueue.
+func NewJetStreamQueueStorage(natsURL string) *JetStreamQueueStorage {
+	nc, err := nats.Connect(natsURL, nats.MaxReconnects(-1))
+	if err != nil {
+		panic(fmt.Errorf("taskqueueworker: cannot connect to nats: %w", err))
+	}
+
+	js, err := jetstream.New(nc)
+	if err != nil {
+		panic(fmt.Errorf("taskqueueworker: cannot init jetstream: %w", err))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	stream, err := js.CreateOrUpdateStream(ctx, jetstream.StreamConfig{
+		Name:      jetStreamStreamName,
+		Subjects:  []string{"candi.taskqueue.>"},
+		Retention: jetstream.WorkQueuePolicy,
+		Storage:   jetstream.FileStorage,
+	})
+	if err != nil {
+		panic(fmt.Errorf("taskqueueworker: cannot create jetstream stream: %w", err))
+	}
+
+	return &JetStreamQueueStorage{nc: nc, js: js, stream: stream}
+}
+
+// IsDurable marks this backend as self-persisting, see DurableQueueStorage.
+func (q *JetStreamQueueStorage) IsDurable() bool {
+	return true
+}
+
+func (q *JetStreamQueueStorage) subject(taskName string) string {
+	return "candi.taskqueue." + taskName
+}
+
+// PushJob publishes the job to its task subject. JetStream keeps the message until a
+// consumer acks it, redelivering on nack or ack-wait timeout, so delivery is at-least-once.
+func (q *JetStreamQueueStorage) PushJob(ctx context.Context, job *Job) {
+	trace := tracer.StartSpan(ctx, "jetstream:push_job")
+	defer trace.Finish()
+
+	payload, err := json.Marshal(job)
+	if err != nil {
+		trace.SetError(err)
+		logger.LogRed("jetstream: failed marshal job: " + err.Error())
+		return
+	}
+
+	if _, err := q.js.Publish(trace.Context(), q.subject(job.TaskName), payload); err != nil {
+		trace.SetError(err)
+		logger.LogRed("jetstream: failed publish job: " + err.Error())
+	}
+}
+
+// PopJob is unused by this backend: jobs are delivered to registerJobToWorker directly by the
+// durable pull consumer started in Subscribe, not pulled synchronously on demand. This backend
+// depends on triggerTask never calling PopJob itself to fetch the job it's about to run -
+// registerJobToWorker handing off the decoded payload is the only way a JetStream-delivered
+// job reaches the worker loop.
+func (q *JetStreamQueueStorage) PopJob(ctx context.Context, taskName string) (job *Job, ok bool) {
+	return nil, false
+}
+
+// Clear is a no-op: JetStream is the durable system of record for this backend, so acked
+// messages are already gone and unacked ones must survive a worker restart rather than be
+// dropped, which is exactly what DurableQueueStorage/prepare() relies on.
+func (q *JetStreamQueueStorage) Clear(ctx context.Context, taskName string) {}
+
+// Subscribe starts a durable pull consumer for taskName and feeds decoded jobs into the
+// worker loop via registerJobToWorker. The JetStream message is only acked once
+// awaitJobOutcome observes the job reach either terminal status (statusSuccess or
+// statusFailure) in Persistent, and left pending otherwise - both while still running and
+// while candi's own retry has put it back to statusRetrying - so AckWait/MaxDeliver, never an
+// explicit Nak, is what drives redelivery if the worker crashes before a terminal status is
+// ever recorded. A message on its jetStreamMaxDeliver-th delivery is written to Persistent as
+// statusFailure and terminated instead of being run again.
+func (q *JetStreamQueueStorage) Subscribe(ctx context.Context, taskName string, workerIndex int) error {
+	consumer, err := q.stream.CreateOrUpdateConsumer(ctx, jetstream.ConsumerConfig{
+		Durable:       "candi-" + taskName,
+		FilterSubject: q.subject(taskName),
+		AckPolicy:     jetstream.AckExplicitPolicy,
+		AckWait:       jetStreamConsumerDelay,
+		MaxDeliver:    jetStreamMaxDeliver,
+	})
+	if err != nil {
+		return fmt.Errorf("taskqueueworker: cannot create jetstream consumer for %q: %w", taskName, err)
+	}
+
+	consumeCtx, err := consumer.Consume(func(msg jetstream.Msg) {
+		var job Job
+		if err := json.Unmarshal(msg.Data(), &job); err != nil {
+			logger.LogRed("jetstream: failed decode job for " + taskName + ": " + err.Error())
+			msg.Term()
+			return
+		}
+
+		meta, _ := msg.Metadata()
+		if meta != nil && meta.NumDelivered >= jetStreamMaxDeliver {
+			job.Status = string(statusFailure)
+			persistent.UpdateJob(ctx, &Filter{JobID: &job.ID}, map[string]interface{}{
+				"status": job.Status,
+			})
+			msg.Term()
+			return
+		}
+
+		rememberDispatchedJob(&job, workerIndex)
+		registerJobToWorker(&job, workerIndex)
+		go q.awaitJobOutcome(ctx, job.ID, msg)
+	})
+	if err != nil {
+		return err
+	}
+
+	q.mu.Lock()
+	q.consumes = append(q.consumes, consumeCtx)
+	q.mu.Unlock()
+	return nil
+}
+
+// Shutdown stops every durable pull consumer started by Subscribe, so no callback goroutine
+// outlives the worker; taskQueueWorker.Shutdown calls this for durable backends instead of
+// Clear, which JetStreamQueueStorage deliberately leaves a no-op. Safe to call even if
+// Subscribe was never invoked for this backend.
+func (q *JetStreamQueueStorage) Shutdown(ctx context.Context) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for _, consumeCtx := range q.consumes {
+		consumeCtx.Stop()
+	}
+	q.consumes = nil
+}
+
+// awaitJobOutcome polls Persistent for jobID's status, keeping the JetStream delivery alive
+// with InProgress while the worker is still processing it, and only then acks it once it
+// reaches either terminal status: statusSuccess, or statusFailure once candi itself has
+// already written it to Persistent as a dead letter - nacking a terminally-failed job would
+// have JetStream redeliver and re-execute it up to jetStreamMaxDeliver times for no reason.
+// statusRetrying is left pending: candi's own internal retry already reschedules that job, so
+// nacking it here too would have JetStream redeliver the same message concurrently and
+// process it twice. Redelivery is reserved for the crash-before-terminal-status case, which
+// AckWait/MaxDeliver drive without this goroutine ever calling Nak. Exits without settling
+// the message when ctx is cancelled (worker shutdown), so this goroutine never outlives the
+// worker; the message is left for JetStream to redeliver to whichever instance picks the
+// consumer back up next.
+func (q *JetStreamQueueStorage) awaitJobOutcome(ctx context.Context, jobID string, msg jetstream.Msg) {
+	ticker := time.NewTicker(jetStreamStatusPoll)
+	defer ticker.Stop()
+
+	deadline := time.Now().Add(jetStreamConsumerDelay / 2)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		var status string
+		StreamAllJob(ctx, &Filter{JobID: &jobID}, func(job *Job) {
+			status = job.Status
+		})
+
+		if jetStreamOutcomeFor(status) == jetStreamOutcomeAck {
+			msg.Ack()
+			return
+		}
+
+		if time.Now().After(deadline) {
+			// still running (or stuck) halfway through the ack-wait window: extend it before
+			// it lapses, JetStream only redelivers once AckWait elapses without progress
+			msg.InProgress()
+			deadline = time.Now().Add(jetStreamConsumerDelay / 2)
+		}
+	}
+}
+
+// jetStreamOutcomeAction is the action awaitJobOutcome should take on a JetStream delivery
+// for a given job status. There is deliberately no "nack" action: redelivery is left entirely
+// to AckWait/MaxDeliver (the crash-before-terminal-status case), never triggered explicitly,
+// so a job already at a terminal status is never re-executed just because of how it got there.
+type jetStreamOutcomeAction int
+
+const (
+	jetStreamOutcomePending jetStreamOutcomeAction = iota
+	jetStreamOutcomeAck
+)
+
+// jetStreamOutcomeFor maps a job's persisted status to the action to take on its JetStream
+// delivery: ack once it reaches either terminal status, statusSuccess or statusFailure (a
+// terminally-failed job is already dead-lettered in Persistent, so redelivering it would only
+// re-execute work candi has already given up on), otherwise keep the delivery pending -
+// notably statusRetrying is pending, not acked, since candi's own internal retry already
+// reschedules that job and it isn't done yet. Split out from awaitJobOutcome so the
+// status/action mapping can be unit tested without a Persistent/jetstream.Msg fake.
+func jetStreamOutcomeFor(status string) jetStreamOutcomeAction {
+	switch status {
+	case string(statusSuccess), string(statusFailure):
+		return jetStreamOutcomeAck
+	default:
+		return jetStreamOutcomePending
+	}
+}
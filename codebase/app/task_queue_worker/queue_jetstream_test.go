@@ -0,0 +1,22 @@
+package taskqueueworker
+
+import "testing"
+
+func TestJetStreamOutcomeFor(t *testing.T) {
+	tests := []struct {
+		status string
+		want   jetStreamOutcomeAction
+	}{
+		{string(statusSuccess), jetStreamOutcomeAck},
+		{string(statusFailure), jetStreamOutcomeAck},
+		{string(statusRetrying), jetStreamOutcomePending},
+		{string(statusQueueing), jetStreamOutcomePending},
+		{"", jetStreamOutcomePending},
+	}
+
+	for _, tt := range tests {
+		if got := jetStreamOutcomeFor(tt.status); got != tt.want {
+			t.Errorf("jetStreamOutcomeFor(%q) = %v, want %v", tt.status, got, tt.want)
+		}
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/golangid/candi/codebase/factory"
 	"github.com/golangid/candi/codebase/factory/types"
 	"github.com/golangid/candi/logger"
+	"github.com/golangid/candi/tracer"
 )
 
 type taskQueueWorker struct {
@@ -25,6 +26,10 @@ type taskQueueWorker struct {
 // NewTaskQueueWorker create new task queue worker
 func NewTaskQueueWorker(service factory.ServiceFactory, opts ...OptionFunc) factory.AppServerFactory {
 	makeAllGlobalVars(opts...)
+	// wrap whichever backend makeAllGlobalVars settled on - the default in-memory/Redis
+	// queue or one configured via SetQueue - so every PushJob stamps the enqueuing span
+	// onto the job, regardless of which (possibly external) code calls it
+	queue = wrapTracingQueueStorage(queue)
 
 	workerInstance := &taskQueueWorker{
 		service: service,
@@ -77,38 +82,74 @@ func (t *taskQueueWorker) prepare() {
 		return
 	}
 
+	trace := tracer.StartSpan(t.ctx, "taskqueueworker:prepare")
+	defer trace.Finish()
+	ctx := trace.Context()
+
+	durable := isDurableQueue(queue)
 	for _, taskName := range tasks {
-		queue.Clear(t.ctx, taskName)
-		persistent.Summary().UpdateSummary(t.ctx, taskName, map[string]interface{}{
+		if !durable {
+			// controllable local/Redis queue: safe to drop whatever is in-flight, the
+			// StreamAllJob resume below will re-push every pending job from Persistent
+			queue.Clear(ctx, taskName)
+		}
+		persistent.Summary().UpdateSummary(ctx, taskName, map[string]interface{}{
 			"is_loading": false,
 		})
 	}
+
+	if durable {
+		// durable backends (JetStream, Kafka, ...) keep their own offset: resume from
+		// there instead of replaying Persistent, so multiple candi instances sharing the
+		// same queue don't duplicate work
+		for _, taskName := range tasks {
+			if subscriber, ok := unwrapQueue(queue).(interface {
+				Subscribe(ctx context.Context, taskName string, workerIndex int) error
+			}); ok {
+				if err := subscriber.Subscribe(ctx, taskName, registeredTask[taskName].workerIndex); err != nil {
+					logger.LogRed("Task Queue Worker: failed subscribe task " + taskName + ": " + err.Error())
+				}
+			}
+		}
+
+		RecalculateSummary(ctx)
+		t.ready <- struct{}{}
+		refreshWorkerNotif <- struct{}{}
+		return
+	}
+
 	// get current pending jobs
 	filter := &Filter{
 		Page: 1, Limit: 10,
 		TaskNameList: tasks,
 		Statuses:     []string{string(statusRetrying), string(statusQueueing)},
 	}
-	StreamAllJob(t.ctx, filter, func(job *Job) {
+	StreamAllJob(ctx, filter, func(job *Job) {
+		trace := startJobSpan(ctx, job)
+		defer trace.Finish()
+		jobCtx := trace.Context()
+
 		// update to queueing
 		if job.Status != string(statusQueueing) {
 			statusBefore := job.Status
 			job.Status = string(statusQueueing)
-			matched, affected, _ := persistent.UpdateJob(t.ctx, &Filter{
+			matched, affected, _ := persistent.UpdateJob(jobCtx, &Filter{
 				JobID: &job.ID,
 			}, map[string]interface{}{
 				"status": job.Status,
 			})
 
-			persistent.Summary().IncrementSummary(t.ctx, job.TaskName, map[string]interface{}{
+			persistent.Summary().IncrementSummary(jobCtx, job.TaskName, map[string]interface{}{
 				statusBefore: -matched, job.Status: affected,
 			})
 		}
-		queue.PushJob(t.ctx, job)
-		registerJobToWorker(job, registeredTask[job.TaskName].workerIndex)
+		queue.PushJob(jobCtx, job)
+		workerIndex := registeredTask[job.TaskName].workerIndex
+		rememberDispatchedJob(job, workerIndex)
+		registerJobToWorker(job, workerIndex)
 	})
 
-	RecalculateSummary(t.ctx)
+	RecalculateSummary(ctx)
 	t.ready <- struct{}{}
 	refreshWorkerNotif <- struct{}{}
 }
@@ -138,7 +179,25 @@ func (t *taskQueueWorker) Serve() {
 			continue
 		}
 
-		go t.triggerTask(chosen)
+		go func(workerIndex int) {
+			// dispatchedJob dequeues (FIFO, see trace.go) whichever job rememberDispatchedJob
+			// queued for this worker slot, so the trace resumed here is for the job this very
+			// dispatch is about to run, not whatever was last queued by the time this
+			// goroutine got scheduled. Falls back to a plain span when nothing was ever
+			// queued (e.g. Serve fires before this slot's first job was pushed).
+			job := dispatchedJob(workerIndex)
+			var trace tracer.Tracer
+			if job != nil {
+				trace = startJobSpan(t.ctx, job)
+			} else {
+				trace = tracer.StartSpan(t.ctx, "taskqueueworker:trigger_task")
+			}
+			defer trace.Finish()
+			// pass the resumed span's context through so triggerTask's own work (and
+			// whatever it calls downstream) attaches as a child of this span instead of
+			// leaving the dashboard timeline with a dangling, childless producer span
+			t.triggerTask(trace.Context(), workerIndex)
+		}(chosen)
 	}
 }
 
@@ -149,8 +208,15 @@ func (t *taskQueueWorker) Shutdown(ctx context.Context) {
 		return
 	}
 
-	for _, task := range tasks {
-		queue.Clear(ctx, task)
+	if !isDurableQueue(queue) {
+		for _, task := range tasks {
+			queue.Clear(ctx, task)
+		}
+	} else if stopper, ok := unwrapQueue(queue).(interface{ Shutdown(ctx context.Context) }); ok {
+		// durable backends (JetStream, ...) run their own background consumers (e.g. pull
+		// subscriptions) that Clear never touches; give them a chance to stop those instead
+		// of leaking callback goroutines past this worker's own shutdown
+		stopper.Shutdown(ctx)
 	}
 	stopAllJob()
 	shutdown <- struct{}{}
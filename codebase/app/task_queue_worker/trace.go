@@ -0,0 +1,103 @@
+package taskqueueworker
+
+import (
+	"context"
+	"sync"
+
+	"github.com/golangid/candi/tracer"
+)
+
+const (
+	tracerTagTraceID = "trace_id"
+)
+
+var (
+	dispatchedJobMu      sync.Mutex
+	dispatchedJobByIndex = make(map[int][]*Job)
+)
+
+// rememberDispatchedJob queues the job registerJobToWorker is about to hand to workerIndex's
+// slot, so Serve's dispatch goroutine can recall it and resume the producer's trace via
+// startJobSpan - triggerTask itself takes no job parameter, so this is the only place that
+// trace can be recovered from on the hot execution path. Queued rather than overwritten: a
+// single *Job keyed by workerIndex would let a second registerJobToWorker call for the same
+// slot clobber the first job's entry before Serve's dispatch goroutine ever reads it, handing
+// the wrong job's trace to whichever one triggerTask actually runs next.
+func rememberDispatchedJob(job *Job, workerIndex int) {
+	dispatchedJobMu.Lock()
+	dispatchedJobByIndex[workerIndex] = append(dispatchedJobByIndex[workerIndex], job)
+	dispatchedJobMu.Unlock()
+}
+
+// dispatchedJob pops the oldest job queued for workerIndex via rememberDispatchedJob, nil if
+// none was ever recorded (e.g. Serve fires before this slot's first job was pushed). FIFO
+// order is what keeps this correlated with the dispatch it's read for: rememberDispatchedJob
+// and the reflect.Select fire that triggers triggerTask happen in the same relative order for
+// a given workerIndex, so the n-th pop always belongs to the n-th dispatch, even if another
+// job is already queued behind it by the time the dispatch goroutine gets around to reading.
+func dispatchedJob(workerIndex int) *Job {
+	dispatchedJobMu.Lock()
+	defer dispatchedJobMu.Unlock()
+
+	queue := dispatchedJobByIndex[workerIndex]
+	if len(queue) == 0 {
+		return nil
+	}
+	job := queue[0]
+	dispatchedJobByIndex[workerIndex] = queue[1:]
+	return job
+}
+
+// stampJobTraceHeader captures the current span's W3C traceparent/baggage onto job.TraceHeader,
+// so startJobSpan can later resume tracing as a child of the span that enqueued it instead of
+// starting a disconnected root span. No-op once a job already carries a header, so resuming an
+// already-queued job (see prepare()) never overwrites its original producer trace.
+func stampJobTraceHeader(ctx context.Context, job *Job) {
+	if len(job.TraceHeader) > 0 {
+		return
+	}
+
+	trace := tracer.StartSpan(ctx, "taskqueueworker:enqueue:"+job.TaskName)
+	defer trace.Finish()
+
+	job.TraceHeader = make(map[string]string)
+	trace.InjectRequestHeader(job.TraceHeader)
+}
+
+// startJobSpan resumes tracing for a dequeued job as a child span of the span that enqueued
+// it, falling back to a new root span when the job carries no trace header (e.g. jobs
+// persisted before this feature existed).
+func startJobSpan(ctx context.Context, job *Job) tracer.Tracer {
+	trace := tracer.StartRootSpan(ctx, "taskqueueworker:"+job.TaskName, job.TraceHeader)
+	trace.SetTag(tracerTagTraceID, tracer.GetTraceID(trace.Context()))
+	trace.SetTag("job.id", job.ID)
+	trace.SetTag("job.retries", job.Retries)
+	return trace
+}
+
+// tracingQueueStorage wraps whichever QueueStorage NewTaskQueueWorker settled on - the
+// default in-memory/Redis queue or one configured via SetQueue - so every PushJob, regardless
+// of backend or which (possibly external) code calls it, stamps the caller's current span
+// onto job.TraceHeader before handing off. PushJob is the one enqueue boundary every caller
+// actually goes through, so wrapping it here is what lets startJobSpan later resume tracing
+// as a child of the request that created the job, instead of requiring every caller to
+// remember to stamp the header itself.
+type tracingQueueStorage struct {
+	QueueStorage
+}
+
+func wrapTracingQueueStorage(q QueueStorage) QueueStorage {
+	return &tracingQueueStorage{QueueStorage: q}
+}
+
+// PushJob implements QueueStorage.
+func (q *tracingQueueStorage) PushJob(ctx context.Context, job *Job) {
+	stampJobTraceHeader(ctx, job)
+	q.QueueStorage.PushJob(ctx, job)
+}
+
+// Unwrap exposes the wrapped backend so isDurableQueue and prepare()'s optional Subscribe
+// detection can still see past this wrapper's own limited method set.
+func (q *tracingQueueStorage) Unwrap() QueueStorage {
+	return q.QueueStorage
+}
@@ -0,0 +1,32 @@
+package streamupload
+
+import (
+	"errors"
+	"strconv"
+)
+
+// ParseGRPCMetadata parses the filename/folder/content_type/size fields a generated
+// gRPC stream-upload handler places in the incoming call's metadata, shared across every
+// stream-upload endpoint so each one doesn't reimplement the same parsing.
+func ParseGRPCMetadata(md map[string][]string) (meta Metadata, err error) {
+	fields := md["filename"]
+	if len(fields) == 0 {
+		return meta, errors.New("streamupload: missing filename field")
+	}
+	meta.Filename = fields[0]
+
+	fields = md["folder"]
+	if len(fields) == 0 {
+		return meta, errors.New("streamupload: missing folder field")
+	}
+	meta.Folder = fields[0]
+
+	if fields = md["content_type"]; len(fields) > 0 {
+		meta.ContentType = fields[0]
+	}
+	if fields = md["size"]; len(fields) > 0 {
+		meta.Size, _ = strconv.ParseInt(fields[0], 10, 64)
+	}
+
+	return meta, nil
+}
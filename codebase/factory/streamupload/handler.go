@@ -0,0 +1,150 @@
+package streamupload
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/golangid/candi/tracer"
+)
+
+// Limits bound what a StreamUploadHandler will accept before Storage is asked to persist a
+// single byte, so an oversized or disallowed file is rejected cheaply.
+type Limits struct {
+	MaxSizeBytes        int64
+	AllowedContentTypes []string
+}
+
+// UsecaseFunc is invoked once the file has been persisted to Storage, typically to save a
+// record and/or enqueue post-upload processing (thumbnailing, virus scan, ...) as a
+// taskqueueworker job carrying the upload's trace context.
+type UsecaseFunc func(ctx context.Context, meta Metadata, url string) error
+
+// ChunkSource yields the next chunk of an upload, abstracting over a gRPC stream's Recv and
+// a multipart.Part's Read so StreamUploadHandler stays transport-agnostic.
+type ChunkSource interface {
+	// Next returns the next chunk, io.EOF once the upload is complete.
+	Next() ([]byte, error)
+}
+
+// FuncChunkSource adapts a plain "read next chunk" function into a ChunkSource. Generated
+// gRPC handlers use this to wrap stream.Recv(), since the concrete request message type
+// differs per proto service and so can't be abstracted behind a shared interface.
+type FuncChunkSource func() ([]byte, error)
+
+// Next implements ChunkSource.
+func (f FuncChunkSource) Next() ([]byte, error) { return f() }
+
+// StreamUploadHandler streams an upload to Storage, validating it against Limits, reporting
+// progress on the active tracer span, then invoking Usecase with the resulting URL. candi's
+// codebase factory generates one of these per gRPC/HTTP upload endpoint instead of each
+// handler hand-rolling metadata parsing and in-memory buffering.
+type StreamUploadHandler struct {
+	Storage Storage
+	Limits  Limits
+	Usecase UsecaseFunc
+}
+
+// NewStreamUploadHandler constructs a StreamUploadHandler, shared by the generated gRPC
+// handler and the HandleHTTPMultipart adapter.
+func NewStreamUploadHandler(storage Storage, limits Limits, usecase UsecaseFunc) *StreamUploadHandler {
+	return &StreamUploadHandler{Storage: storage, Limits: limits, Usecase: usecase}
+}
+
+// Handle validates meta against Limits, then streams chunks from src to Storage without
+// buffering the whole file in memory, before handing the stored URL to Usecase.
+func (h *StreamUploadHandler) Handle(ctx context.Context, meta Metadata, src ChunkSource) (url string, err error) {
+	trace := tracer.StartSpan(ctx, "streamupload:handle")
+	defer func() { trace.Finish(tracer.SetErrorFinishOption(err)) }()
+
+	if err = h.validate(&meta); err != nil {
+		return "", err
+	}
+	trace.SetTag("upload.filename", meta.Filename)
+	trace.SetTag("upload.folder", meta.Folder)
+
+	pr, pw := io.Pipe()
+	go h.pump(trace.Context(), src, pw)
+
+	url, err = h.Storage.Save(trace.Context(), meta, pr)
+	if err != nil {
+		return "", err
+	}
+
+	if h.Usecase != nil {
+		err = h.Usecase(trace.Context(), meta, url)
+	}
+	return url, err
+}
+
+// pump reads chunks from src and writes them to pw, closing pw with the terminal error
+// (nil on a clean io.EOF) once src is exhausted or the size limit is exceeded.
+func (h *StreamUploadHandler) pump(ctx context.Context, src ChunkSource, pw *io.PipeWriter) {
+	var written int64
+	for {
+		chunk, readErr := src.Next()
+		if len(chunk) > 0 {
+			written += int64(len(chunk))
+			if h.Limits.MaxSizeBytes > 0 && written > h.Limits.MaxSizeBytes {
+				pw.CloseWithError(fmt.Errorf("streamupload: file exceeds max size of %d bytes", h.Limits.MaxSizeBytes))
+				return
+			}
+			if _, err := pw.Write(chunk); err != nil {
+				pw.CloseWithError(err)
+				return
+			}
+			tracer.LogEvent(ctx, "upload.progress", written)
+		}
+
+		switch readErr {
+		case nil:
+			continue
+		case io.EOF:
+			pw.Close()
+		default:
+			pw.CloseWithError(readErr)
+		}
+		return
+	}
+}
+
+// validate rejects meta against Limits, then sanitizes Filename/Folder in place so no
+// Storage implementation ever sees a path that can escape its intended root: Filename is
+// reduced to its base name (stripping any directory component or ".." segment) and Folder is
+// rejected outright if it's absolute or attempts to climb above its root.
+func (h *StreamUploadHandler) validate(meta *Metadata) error {
+	if h.Limits.MaxSizeBytes > 0 && meta.Size > h.Limits.MaxSizeBytes {
+		return fmt.Errorf("streamupload: file size %d exceeds max allowed %d", meta.Size, h.Limits.MaxSizeBytes)
+	}
+	if len(h.Limits.AllowedContentTypes) > 0 {
+		var allowed bool
+		for _, ct := range h.Limits.AllowedContentTypes {
+			if ct == meta.ContentType {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return fmt.Errorf("streamupload: content type %q is not allowed", meta.ContentType)
+		}
+	}
+
+	meta.Filename = filepath.Base(meta.Filename)
+	switch meta.Filename {
+	case "", ".", "/", "..":
+		return fmt.Errorf("streamupload: invalid filename %q", meta.Filename)
+	}
+
+	if folder := filepath.Clean(meta.Folder); folder != "." {
+		if filepath.IsAbs(folder) || folder == ".." || strings.HasPrefix(folder, ".."+string(filepath.Separator)) {
+			return fmt.Errorf("streamupload: invalid folder %q", meta.Folder)
+		}
+		meta.Folder = folder
+	} else {
+		meta.Folder = ""
+	}
+
+	return nil
+}
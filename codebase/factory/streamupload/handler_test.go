@@ -0,0 +1,84 @@
+package streamupload
+
+import "testing"
+
+func TestStreamUploadHandlerValidate(t *testing.T) {
+	tests := []struct {
+		name         string
+		meta         Metadata
+		wantErr      bool
+		wantFilename string
+		wantFolder   string
+	}{
+		{
+			name:         "plain filename and folder are untouched",
+			meta:         Metadata{Filename: "photo.png", Folder: "avatars"},
+			wantFilename: "photo.png",
+			wantFolder:   "avatars",
+		},
+		{
+			name:         "filename path traversal is reduced to its base name",
+			meta:         Metadata{Filename: "../../../../etc/cron.d/x", Folder: "avatars"},
+			wantFilename: "x",
+			wantFolder:   "avatars",
+		},
+		{
+			name:    "folder path traversal is rejected",
+			meta:    Metadata{Filename: "photo.png", Folder: "../../etc"},
+			wantErr: true,
+		},
+		{
+			name:    "absolute folder is rejected",
+			meta:    Metadata{Filename: "photo.png", Folder: "/etc"},
+			wantErr: true,
+		},
+		{
+			name:    "empty filename is rejected",
+			meta:    Metadata{Filename: "", Folder: "avatars"},
+			wantErr: true,
+		},
+		{
+			name:         "empty folder is normalized to empty",
+			meta:         Metadata{Filename: "photo.png", Folder: ""},
+			wantFilename: "photo.png",
+			wantFolder:   "",
+		},
+	}
+
+	h := NewStreamUploadHandler(nil, Limits{}, nil)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			meta := tt.meta
+			err := h.validate(&meta)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("validate() error = nil, want error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("validate() error = %v, want nil", err)
+			}
+			if meta.Filename != tt.wantFilename {
+				t.Errorf("Filename = %q, want %q", meta.Filename, tt.wantFilename)
+			}
+			if meta.Folder != tt.wantFolder {
+				t.Errorf("Folder = %q, want %q", meta.Folder, tt.wantFolder)
+			}
+		})
+	}
+}
+
+func TestStreamUploadHandlerValidateContentType(t *testing.T) {
+	h := NewStreamUploadHandler(nil, Limits{AllowedContentTypes: []string{"image/png"}}, nil)
+
+	meta := Metadata{Filename: "photo.png", ContentType: "image/png"}
+	if err := h.validate(&meta); err != nil {
+		t.Fatalf("validate() error = %v, want nil for allowed content type", err)
+	}
+
+	meta = Metadata{Filename: "photo.exe", ContentType: "application/octet-stream"}
+	if err := h.validate(&meta); err == nil {
+		t.Fatal("validate() error = nil, want error for disallowed content type")
+	}
+}
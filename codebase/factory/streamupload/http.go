@@ -0,0 +1,66 @@
+package streamupload
+
+import (
+	"errors"
+	"io"
+	"mime/multipart"
+	"net/http"
+)
+
+// maxFolderFieldBytes bounds the "folder" form field read while scanning for the "file" part,
+// so a client can't force an unbounded read into memory by sending a huge non-file field.
+const maxFolderFieldBytes = 1024
+
+// HandleHTTPMultipart streams the first "file" part of an incoming multipart/form-data
+// request through h, so the same usecase wired for a gRPC stream-upload endpoint can also
+// serve REST clients. The body is read exactly once via MultipartReader, so a "folder" field
+// must come from that same stream rather than r.FormValue: calling ParseMultipartForm after
+// the streaming reader has already consumed (part of) the body returns nothing, which would
+// silently land every upload in the storage root.
+func HandleHTTPMultipart(h *StreamUploadHandler, r *http.Request) (url string, err error) {
+	reader, err := r.MultipartReader()
+	if err != nil {
+		return "", err
+	}
+
+	var folder string
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			return "", errors.New("streamupload: no file part found in multipart request")
+		}
+		if err != nil {
+			return "", err
+		}
+
+		if part.FormName() == "folder" {
+			value, err := io.ReadAll(io.LimitReader(part, maxFolderFieldBytes))
+			if err != nil {
+				return "", err
+			}
+			folder = string(value)
+			continue
+		}
+		if part.FormName() != "file" {
+			continue
+		}
+
+		meta := Metadata{
+			Filename:    part.FileName(),
+			Folder:      folder,
+			ContentType: part.Header.Get("Content-Type"),
+			Size:        r.ContentLength,
+		}
+		return h.Handle(r.Context(), meta, &multipartChunkSource{part: part})
+	}
+}
+
+type multipartChunkSource struct {
+	part *multipart.Part
+}
+
+func (s *multipartChunkSource) Next() ([]byte, error) {
+	buf := make([]byte, 32*1024)
+	n, err := s.part.Read(buf)
+	return buf[:n], err
+}
@@ -0,0 +1,90 @@
+package streamupload
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type fakeStorage struct {
+	savedMeta    Metadata
+	savedContent []byte
+}
+
+func (s *fakeStorage) Save(ctx context.Context, meta Metadata, content io.Reader) (string, error) {
+	b, err := io.ReadAll(content)
+	if err != nil {
+		return "", err
+	}
+	s.savedMeta = meta
+	s.savedContent = b
+	return "http://example.com/" + meta.Folder + "/" + meta.Filename, nil
+}
+
+func newMultipartRequest(t *testing.T, folder, filename, content string) *http.Request {
+	t.Helper()
+
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	if folder != "" {
+		if err := w.WriteField("folder", folder); err != nil {
+			t.Fatalf("WriteField() error = %v", err)
+		}
+	}
+	part, err := w.CreateFormFile("file", filename)
+	if err != nil {
+		t.Fatalf("CreateFormFile() error = %v", err)
+	}
+	if _, err := part.Write([]byte(content)); err != nil {
+		t.Fatalf("part.Write() error = %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/upload", &body)
+	req.Header.Set("Content-Type", w.FormDataContentType())
+	return req
+}
+
+func TestHandleHTTPMultipart(t *testing.T) {
+	storage := &fakeStorage{}
+	h := NewStreamUploadHandler(storage, Limits{}, nil)
+
+	req := newMultipartRequest(t, "avatars", "photo.png", "hello world")
+	url, err := HandleHTTPMultipart(h, req)
+	if err != nil {
+		t.Fatalf("HandleHTTPMultipart() error = %v", err)
+	}
+
+	if storage.savedMeta.Folder != "avatars" {
+		t.Errorf("Folder = %q, want %q (streaming the body must not depend on r.FormValue)", storage.savedMeta.Folder, "avatars")
+	}
+	if storage.savedMeta.Filename != "photo.png" {
+		t.Errorf("Filename = %q, want %q", storage.savedMeta.Filename, "photo.png")
+	}
+	if string(storage.savedContent) != "hello world" {
+		t.Errorf("content = %q, want %q", storage.savedContent, "hello world")
+	}
+	if url == "" {
+		t.Error("url is empty, want the storage-returned url")
+	}
+}
+
+func TestHandleHTTPMultipartNoFolder(t *testing.T) {
+	storage := &fakeStorage{}
+	h := NewStreamUploadHandler(storage, Limits{}, nil)
+
+	req := newMultipartRequest(t, "", "photo.png", "hello")
+	if _, err := HandleHTTPMultipart(h, req); err != nil {
+		t.Fatalf("HandleHTTPMultipart() error = %v", err)
+	}
+
+	if storage.savedMeta.Folder != "" {
+		t.Errorf("Folder = %q, want empty", storage.savedMeta.Folder)
+	}
+}
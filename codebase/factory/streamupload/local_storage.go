@@ -0,0 +1,55 @@
+package streamupload
+
+import (
+	"context"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// LocalStorage saves uploads under a directory on the local filesystem. Useful for local
+// development and single-instance deployments; multi-instance deployments should use an
+// S3- or GCS-backed Storage instead so every instance sees the same files.
+type LocalStorage struct {
+	BaseDir string
+	BaseURL string
+}
+
+// NewLocalStorage constructs a LocalStorage rooted at baseDir, serving saved files under baseURL.
+func NewLocalStorage(baseDir, baseURL string) *LocalStorage {
+	return &LocalStorage{BaseDir: baseDir, BaseURL: baseURL}
+}
+
+// Save streams content to BaseDir/meta.Folder/meta.Filename.
+func (s *LocalStorage) Save(ctx context.Context, meta Metadata, content io.Reader) (url string, err error) {
+	dir := filepath.Join(s.BaseDir, meta.Folder)
+	if err = os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+
+	dst := filepath.Join(dir, meta.Filename)
+	f, err := os.Create(dst)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	if _, err = io.Copy(f, content); err != nil {
+		return "", err
+	}
+
+	return buildLocalStorageURL(s.BaseURL, meta.Folder, meta.Filename), nil
+}
+
+// buildLocalStorageURL joins baseURL with folder/filename, omitting folder when validate
+// normalized it to "" - path.Join on the full URL would collapse baseURL's own "://" along
+// with it, so only the relative part is joined before being appended.
+func buildLocalStorageURL(baseURL, folder, filename string) string {
+	rel := filename
+	if folder != "" {
+		rel = path.Join(folder, filename)
+	}
+	return strings.TrimSuffix(baseURL, "/") + "/" + rel
+}
@@ -0,0 +1,36 @@
+package streamupload
+
+import "testing"
+
+func TestBuildLocalStorageURL(t *testing.T) {
+	tests := []struct {
+		name             string
+		baseURL          string
+		folder, filename string
+		want             string
+	}{
+		{
+			name: "folder and filename are joined", baseURL: "https://cdn.example.com",
+			folder: "avatars", filename: "photo.png",
+			want: "https://cdn.example.com/avatars/photo.png",
+		},
+		{
+			name: "empty folder is omitted, no double slash", baseURL: "https://cdn.example.com",
+			folder: "", filename: "photo.png",
+			want: "https://cdn.example.com/photo.png",
+		},
+		{
+			name: "trailing slash on baseURL is not duplicated", baseURL: "https://cdn.example.com/",
+			folder: "avatars", filename: "photo.png",
+			want: "https://cdn.example.com/avatars/photo.png",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := buildLocalStorageURL(tt.baseURL, tt.folder, tt.filename); got != tt.want {
+				t.Errorf("buildLocalStorageURL() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
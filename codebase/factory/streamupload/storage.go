@@ -0,0 +1,24 @@
+package streamupload
+
+import (
+	"context"
+	"io"
+)
+
+// Metadata describes the file being uploaded, parsed from gRPC metadata or an HTTP
+// multipart form before any content is streamed to a Storage sink.
+type Metadata struct {
+	Filename    string
+	Folder      string
+	ContentType string
+	Size        int64
+}
+
+// Storage is a pluggable sink that receives upload content as a stream, so a
+// StreamUploadHandler never has to buffer the whole file in memory. Implementations ship
+// for the local filesystem (NewLocalStorage); S3 and GCS sinks can be added the same way.
+type Storage interface {
+	// Save streams content to the sink under meta.Folder/meta.Filename and returns the
+	// resulting public URL.
+	Save(ctx context.Context, meta Metadata, content io.Reader) (url string, err error)
+}
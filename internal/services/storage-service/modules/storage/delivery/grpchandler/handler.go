@@ -1,13 +1,12 @@
 package grpchandler
 
 import (
-	"errors"
-	"io"
-	"strconv"
+	"context"
 
 	pb "agungdwiprasetyo.com/backend-microservices/api/proto/storage-service"
 	"agungdwiprasetyo.com/backend-microservices/internal/services/storage-service/modules/storage/domain"
 	"agungdwiprasetyo.com/backend-microservices/internal/services/storage-service/modules/storage/usecase"
+	"github.com/golangid/candi/codebase/factory/streamupload"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
@@ -15,14 +14,20 @@ import (
 
 // GRPCHandler rpc stream
 type GRPCHandler struct {
-	uc usecase.StorageUsecase
+	uc      usecase.StorageUsecase
+	storage streamupload.Storage
+	limits  streamupload.Limits
 }
 
-// NewGRPCHandler func
-func NewGRPCHandler(uc usecase.StorageUsecase) *GRPCHandler {
+// NewGRPCHandler func. limits is enforced on every Upload stream, rejecting a file once it
+// exceeds MaxSizeBytes or arrives with a ContentType not in AllowedContentTypes; pass the
+// zero value only if this service genuinely has no upload restrictions to enforce.
+func NewGRPCHandler(uc usecase.StorageUsecase, storage streamupload.Storage, limits streamupload.Limits) *GRPCHandler {
 
 	return &GRPCHandler{
-		uc: uc,
+		uc:      uc,
+		storage: storage,
+		limits:  limits,
 	}
 }
 
@@ -31,65 +36,49 @@ func (h *GRPCHandler) Register(server *grpc.Server) {
 	pb.RegisterUploadServiceServer(server, h)
 }
 
-// Upload method
+// Upload method, streams the incoming file straight to storage via candi's reusable
+// StreamUploadHandler instead of buffering the whole payload in memory
 func (h *GRPCHandler) Upload(stream pb.UploadService_UploadServer) (err error) {
 
 	ctx := stream.Context()
-	meta, ok := metadata.FromIncomingContext(ctx)
+	md, ok := metadata.FromIncomingContext(ctx)
 	if !ok {
 		return grpc.Errorf(codes.Unauthenticated, "missing context metadata")
 	}
 
-	fields := meta.Get("filename")
-	if len(fields) == 0 {
-		return errors.New("missing filename field")
+	meta, err := streamupload.ParseGRPCMetadata(md)
+	if err != nil {
+		return err
 	}
-	fileName := fields[0]
 
-	fields = meta.Get("folder")
-	if len(fields) == 0 {
-		return errors.New("missing folder field")
-	}
-	folder := fields[0]
-
-	var contentType string
-	if u := meta.Get("content_type"); len(u) > 0 {
-		contentType = u[0]
-	}
+	handler := streamupload.NewStreamUploadHandler(h.storage, h.limits,
+		func(ctx context.Context, meta streamupload.Metadata, url string) error {
+			res := <-h.uc.Upload(ctx, url, &domain.UploadMetadata{
+				ContentType: meta.ContentType,
+				FileSize:    meta.Size,
+				Filename:    meta.Filename,
+			})
+			return res.Error
+		},
+	)
 
 	var size int64
-	if u := meta.Get("size"); len(u) > 0 {
-		s, _ := strconv.Atoi(u[0])
-		size = int64(s)
-	}
-
-	var buff []byte
-	for {
+	url, err := handler.Handle(ctx, meta, streamupload.FuncChunkSource(func() ([]byte, error) {
 		res, err := stream.Recv()
-		if err == io.EOF {
-			break
+		if err != nil {
+			return nil, err
 		}
-
-		buff = append(buff, res.Content...)
-	}
-
-	res := <-h.uc.Upload(ctx,
-		buff,
-		&domain.UploadMetadata{
-			ContentType: contentType,
-			FileSize:    size,
-			Filename:    fileName,
-		})
-	if res.Error != nil {
-		return grpc.Errorf(codes.Internal, "%v", res.Error)
+		size += int64(len(res.Content))
+		return res.Content, nil
+	}))
+	if err != nil {
+		return grpc.Errorf(codes.Internal, "%v", err)
 	}
 
-	err = stream.SendAndClose(&pb.UploadStatus{
+	return stream.SendAndClose(&pb.UploadStatus{
 		Message: "Stream file success",
 		Code:    pb.UploadStatusCode_Ok,
-		File:    "url" + "/" + folder + "/" + fileName,
-		Size:    int64(len(buff)),
+		File:    url,
+		Size:    size,
 	})
-
-	return
-}
\ No newline at end of file
+}
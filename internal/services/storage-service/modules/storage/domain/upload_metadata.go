@@ -0,0 +1,9 @@
+package domain
+
+// UploadMetadata describes a file that has already been (or is being) uploaded, passed to
+// usecase.StorageUsecase.Upload alongside the stored file's url.
+type UploadMetadata struct {
+	ContentType string
+	FileSize    int64
+	Filename    string
+}
@@ -0,0 +1,21 @@
+package usecase
+
+import (
+	"context"
+
+	"agungdwiprasetyo.com/backend-microservices/internal/services/storage-service/modules/storage/domain"
+)
+
+// UploadResult is delivered on the channel returned by StorageUsecase.Upload once the
+// post-upload step (persisting a record, enqueueing further processing, ...) finishes.
+type UploadResult struct {
+	Error error
+}
+
+// StorageUsecase is the storage-service module's business logic, invoked once a file has
+// already been streamed to its storage sink (see codebase/factory/streamupload).
+type StorageUsecase interface {
+	// Upload records an already-stored file, identified by its url, and runs any post-upload
+	// processing, delivering the outcome on the returned channel.
+	Upload(ctx context.Context, url string, meta *domain.UploadMetadata) <-chan UploadResult
+}
@@ -0,0 +1,38 @@
+package tracer
+
+import (
+	"context"
+	"fmt"
+)
+
+// Tracer abstract tracer platform implementation (jaeger, opentelemetry, ...)
+type Tracer interface {
+	// Context get active context
+	Context() context.Context
+	// Tags get tags data in span
+	Tags() map[string]interface{}
+	// SetTag set new tag in span
+	SetTag(key string, value interface{})
+	// InjectRequestHeader inject current span context into a carrier header, for continue tracing in next process
+	InjectRequestHeader(header map[string]string)
+	// SetError set error in span
+	SetError(err error)
+	// Log set log in span
+	Log(key string, value interface{})
+	// Finish trace with additional tags data, must in deferred function
+	Finish(opts ...FinishOptionFunc)
+}
+
+// toValue cast value to proper type so it can be safely logged/tagged by any tracer backend
+func toValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case error:
+		return v.Error()
+	case fmt.Stringer:
+		return v.String()
+	default:
+		return v
+	}
+}
@@ -0,0 +1,322 @@
+package tracer
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"runtime"
+	"strings"
+
+	"github.com/golangid/candi"
+	"github.com/golangid/candi/config/env"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// platformOpenTelemetry is the registry name for the OpenTelemetry/OTLP backend.
+const platformOpenTelemetry = "opentelemetry"
+
+var otelPropagator = propagation.NewCompositeTextMapPropagator(
+	propagation.TraceContext{}, propagation.Baggage{},
+)
+
+// InitOpenTelemetry init tracing backed by an OpenTelemetry SDK exporting spans via OTLP,
+// to any OTel collector (Jaeger, Tempo, Zipkin, Datadog, Honeycomb via collector, ...).
+//
+// Endpoint and resource attributes follow the standard OpenTelemetry env vars
+// (OTEL_EXPORTER_OTLP_ENDPOINT, OTEL_EXPORTER_OTLP_PROTOCOL, OTEL_SERVICE_NAME,
+// OTEL_RESOURCE_ATTRIBUTES). When set, OTEL_EXPORTER_OTLP_ENDPOINT and OTEL_SERVICE_NAME take
+// precedence over the agent host and service name given via arguments and OptionFunc.
+func InitOpenTelemetry(serviceName string, opts ...OptionFunc) error {
+	option := Option{
+		AgentHost:       env.BaseEnv().JaegerTracingHost,
+		Level:           env.BaseEnv().Environment,
+		BuildNumberTag:  env.BaseEnv().BuildNumber,
+		MaxGoroutineTag: env.BaseEnv().MaxGoroutines,
+	}
+	for _, opt := range opts {
+		opt(&option)
+	}
+
+	if option.Level != "" {
+		serviceName = fmt.Sprintf("%s-%s", serviceName, strings.ToLower(option.Level))
+	}
+	if envName := os.Getenv("OTEL_SERVICE_NAME"); envName != "" {
+		serviceName = envName
+	}
+
+	exporter, err := newOTLPExporter(context.Background(), option)
+	if err != nil {
+		log.Printf("ERROR: cannot init opentelemetry connection: %v\n", err)
+		return err
+	}
+
+	attrs := []attribute.KeyValue{
+		semconv.ServiceNameKey.String(serviceName),
+		attribute.Int("num_cpu", runtime.NumCPU()),
+		attribute.String("go_version", runtime.Version()),
+		attribute.String("candi_version", candi.Version),
+	}
+	if option.MaxGoroutineTag != 0 {
+		attrs = append(attrs, attribute.Int("max_goroutines", option.MaxGoroutineTag))
+	}
+	if option.BuildNumberTag != "" {
+		attrs = append(attrs, attribute.String("build_number", option.BuildNumberTag))
+	}
+	attrs = append(attrs, parseOTLPResourceAttributes(os.Getenv("OTEL_RESOURCE_ATTRIBUTES"))...)
+	res := newOTLPResource(attrs...)
+
+	tracerProvider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(buildSampler(option)),
+	)
+	otel.SetTracerProvider(tracerProvider)
+	otel.SetTextMapPropagator(otelPropagator)
+
+	otelBackend := &otelPlatform{tracer: tracerProvider.Tracer("github.com/golangid/candi")}
+	RegisterTracerPlatformType(platformOpenTelemetry, otelBackend)
+	SetTracerPlatformType(otelBackend)
+	return nil
+}
+
+func newOTLPExporter(ctx context.Context, option Option) (sdktrace.SpanExporter, error) {
+	endpoint := option.AgentHost
+	if envEndpoint := os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT"); envEndpoint != "" {
+		endpoint = envEndpoint
+	}
+
+	if strings.EqualFold(os.Getenv("OTEL_EXPORTER_OTLP_PROTOCOL"), "http/protobuf") {
+		client := otlptracehttp.NewClient(
+			otlptracehttp.WithEndpoint(endpoint),
+			otlptracehttp.WithInsecure(),
+		)
+		return otlptrace.New(ctx, client)
+	}
+
+	client := otlptracegrpc.NewClient(
+		otlptracegrpc.WithEndpoint(endpoint),
+		otlptracegrpc.WithInsecure(),
+	)
+	return otlptrace.New(ctx, client)
+}
+
+type otelPlatform struct {
+	tracer trace.Tracer
+}
+
+func (o *otelPlatform) StartSpan(ctx context.Context, operationName string) Tracer {
+	ctx, span := o.tracer.Start(ctx, operationName)
+	return &otelTraceImpl{ctx: ctx, span: span}
+}
+
+func (o *otelPlatform) StartRootSpan(ctx context.Context, operationName string, header map[string]string) Tracer {
+	if header == nil {
+		header = map[string]string{}
+	}
+
+	ctx = otelPropagator.Extract(ctx, propagation.MapCarrier(header))
+	ctx, span := o.tracer.Start(ctx, operationName, trace.WithSpanKind(trace.SpanKindServer))
+	return &otelTraceImpl{ctx: ctx, span: span}
+}
+
+func (o *otelPlatform) GetTraceID(ctx context.Context) string {
+	spanCtx := trace.SpanContextFromContext(ctx)
+	if !spanCtx.IsValid() {
+		return ""
+	}
+	return spanCtx.TraceID().String()
+}
+
+func (o *otelPlatform) GetTraceURL(ctx context.Context) string {
+	return o.BuildTraceURL(o.GetTraceID(ctx))
+}
+
+func (o *otelPlatform) BuildTraceURL(traceID string) (u string) {
+	if traceID == "" {
+		return
+	}
+	if endpoint := os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT"); endpoint != "" {
+		u = fmt.Sprintf("%s/trace/%s", strings.TrimSuffix(endpoint, "/"), traceID)
+	}
+	return
+}
+
+// Log implements TracerPlatformType.
+func (o *otelPlatform) Log(ctx context.Context, key string, value interface{}) {
+	span := trace.SpanFromContext(ctx)
+	if !span.IsRecording() {
+		return
+	}
+	span.AddEvent(key, trace.WithAttributes(toOTLPAttribute(key, value)))
+}
+
+// LogEvent implements TracerPlatformType.
+func (o *otelPlatform) LogEvent(ctx context.Context, event string, payload ...interface{}) {
+	span := trace.SpanFromContext(ctx)
+	if !span.IsRecording() {
+		return
+	}
+
+	if len(payload) == 0 {
+		span.AddEvent(event)
+		return
+	}
+	for _, p := range payload {
+		if e, ok := p.(error); ok && e != nil {
+			span.RecordError(e)
+			span.SetStatus(codes.Error, e.Error())
+		}
+		span.AddEvent(event, trace.WithAttributes(toOTLPAttribute(event, p)))
+	}
+}
+
+type otelTraceImpl struct {
+	ctx  context.Context
+	span trace.Span
+	tags map[string]interface{}
+}
+
+// Context get active context
+func (t *otelTraceImpl) Context() context.Context {
+	return t.ctx
+}
+
+// Tags create tags in tracer span
+func (t *otelTraceImpl) Tags() map[string]interface{} {
+	if t.tags == nil {
+		t.tags = make(map[string]interface{})
+	}
+	return t.tags
+}
+
+// SetTag set tags in tracer span
+func (t *otelTraceImpl) SetTag(key string, value interface{}) {
+	if t.tags == nil {
+		t.tags = make(map[string]interface{})
+	}
+	t.tags[key] = value
+}
+
+// InjectRequestHeader to continue tracer with custom header carrier, propagating W3C traceparent + baggage
+func (t *otelTraceImpl) InjectRequestHeader(header map[string]string) {
+	if header == nil {
+		return
+	}
+	otelPropagator.Inject(t.ctx, propagation.MapCarrier(header))
+}
+
+// SetError set error in span
+func (t *otelTraceImpl) SetError(err error) {
+	if t.span == nil || err == nil {
+		return
+	}
+	t.span.RecordError(err)
+	t.span.SetStatus(codes.Error, err.Error())
+}
+
+// Log log data
+func (t *otelTraceImpl) Log(key string, value interface{}) {
+	if t.span == nil {
+		return
+	}
+	t.span.AddEvent(key, trace.WithAttributes(toOTLPAttribute(key, value)))
+}
+
+// Finish trace with additional tags data, must in deferred function
+func (t *otelTraceImpl) Finish(opts ...FinishOptionFunc) {
+	if t.span == nil {
+		return
+	}
+
+	var finishOpt FinishOption
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&finishOpt)
+		}
+	}
+
+	if t.tags == nil && finishOpt.Tags != nil {
+		t.tags = make(map[string]interface{})
+	}
+	for k, v := range finishOpt.Tags {
+		t.tags[k] = v
+	}
+	for k, v := range t.tags {
+		t.span.SetAttributes(toOTLPAttribute(k, v))
+	}
+
+	t.SetError(finishOpt.Error)
+	t.span.End()
+}
+
+func newOTLPResource(attrs ...attribute.KeyValue) *resource.Resource {
+	return resource.NewSchemaless(attrs...)
+}
+
+// parseOTLPResourceAttributes parses the comma-separated key=value pairs from
+// OTEL_RESOURCE_ATTRIBUTES, as defined by the OpenTelemetry resource SDK spec.
+func parseOTLPResourceAttributes(raw string) (attrs []attribute.KeyValue) {
+	if raw == "" {
+		return
+	}
+	for _, pair := range strings.Split(raw, ",") {
+		kv := strings.SplitN(pair, "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		attrs = append(attrs, attribute.String(strings.TrimSpace(kv[0]), strings.TrimSpace(kv[1])))
+	}
+	return
+}
+
+// buildSampler builds the head-based OTel sampler, defaulting to always-on so existing
+// behavior is unchanged unless a sampler is explicitly configured via OptionFunc.
+func buildSampler(option Option) sdktrace.Sampler {
+	if option.Sampler.Type == "" {
+		return sdktrace.AlwaysSample()
+	}
+
+	switch option.Sampler.Type {
+	case SamplerTypeRatio:
+		return sdktrace.ParentBased(sdktrace.TraceIDRatioBased(option.Sampler.Param))
+	case SamplerTypeAlwaysOff:
+		return sdktrace.NeverSample()
+	case SamplerTypeConst:
+		// Param is documented (option.go) as 0 or 1 for SamplerTypeConst: falling through to
+		// the always-on default would silently sample everything for Param == 0
+		if option.Sampler.Param == 0 {
+			return sdktrace.NeverSample()
+		}
+		return sdktrace.AlwaysSample()
+	default:
+		return sdktrace.AlwaysSample()
+	}
+}
+
+func toOTLPAttribute(key string, value interface{}) attribute.KeyValue {
+	switch v := toValue(value).(type) {
+	case string:
+		return attribute.String(key, v)
+	case bool:
+		return attribute.Bool(key, v)
+	case int:
+		return attribute.Int(key, v)
+	case int64:
+		return attribute.Int64(key, v)
+	case float64:
+		return attribute.Float64(key, v)
+	default:
+		return attribute.String(key, fmt.Sprintf("%v", v))
+	}
+}
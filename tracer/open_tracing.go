@@ -6,7 +6,9 @@ import (
 	"log"
 	"math"
 	"net/url"
+	"os"
 	"runtime"
+	"strconv"
 	"strings"
 	"time"
 
@@ -15,6 +17,7 @@ import (
 	opentracing "github.com/opentracing/opentracing-go"
 	ext "github.com/opentracing/opentracing-go/ext"
 	otlog "github.com/opentracing/opentracing-go/log"
+	jaegerclient "github.com/uber/jaeger-client-go"
 	config "github.com/uber/jaeger-client-go/config"
 )
 
@@ -49,11 +52,16 @@ func InitOpenTracing(serviceName string, opts ...OptionFunc) error {
 			Key: "build_number", Value: option.BuildNumberTag,
 		})
 	}
+	sampler := option.Sampler
+	if sampler.Type == "" {
+		sampler.Type = envOrDefault("JAEGER_SAMPLER_TYPE", SamplerTypeConst)
+		sampler.Param = 1
+		if param, err := strconv.ParseFloat(envOrDefault("JAEGER_SAMPLER_PARAM", "1"), 64); err == nil {
+			sampler.Param = param
+		}
+	}
+
 	cfg := &config.Configuration{
-		Sampler: &config.SamplerConfig{
-			Type:  "const",
-			Param: 1,
-		},
 		Reporter: &config.ReporterConfig{
 			LogSpans:            true,
 			BufferFlushInterval: 1 * time.Second,
@@ -62,16 +70,125 @@ func InitOpenTracing(serviceName string, opts ...OptionFunc) error {
 		ServiceName: serviceName,
 		Tags:        defaultTags,
 	}
-	tracer, _, err := cfg.NewTracer(config.MaxTagValueLength(math.MaxInt32))
+
+	var tracerOpts []config.Option
+	if sampler.Type == SamplerTypeRemote {
+		// SamplerTypeRemote fetches strategies from the Jaeger agent itself: this is the one
+		// case config.SamplerConfig's own (string-typed) construction handles correctly, a
+		// custom jaeger.Sampler can't express polling the agent the same way
+		cfg.Sampler = &config.SamplerConfig{
+			Type:                    sampler.Type,
+			Param:                   sampler.Param,
+			SamplingServerURL:       sampler.SamplingServerURL,
+			SamplingRefreshInterval: 10 * time.Second,
+		}
+	} else {
+		// build the jaeger.Sampler ourselves instead of handing config.Configuration a raw
+		// Type string: SamplerTypeRatio/AlwaysOn/AlwaysOff aren't jaeger-client-go type
+		// strings at all, so passing them through would silently fail to sample as configured
+		baseSampler, err := newJaegerSampler(sampler)
+		if err != nil {
+			log.Printf("ERROR: cannot init opentracing sampler: %v\n", err)
+			return err
+		}
+		if len(sampler.PerOperation) > 0 {
+			baseSampler = &perOperationSampler{defaultSampler: baseSampler, samplers: buildPerOperationSamplers(sampler.PerOperation)}
+		}
+		tracerOpts = append(tracerOpts, config.Sampler(baseSampler))
+	}
+
+	tracer, _, err := cfg.NewTracer(append(tracerOpts, config.MaxTagValueLength(math.MaxInt32))...)
 	if err != nil {
 		log.Printf("ERROR: cannot init opentracing connection: %v\n", err)
 		return err
 	}
 	opentracing.SetGlobalTracer(tracer)
-	SetTracerPlatformType(&jaegerPlatform{})
+
+	jaeger := &jaegerPlatform{}
+	RegisterTracerPlatformType(platformJaeger, jaeger)
+	SetTracerPlatformType(jaeger)
 	return nil
 }
 
+func envOrDefault(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
+
+// newJaegerSampler builds the concrete jaeger.Sampler matching cfg.Type, used as the default
+// decision for operations without a PerOperation override. Accepts both jaeger-client-go's
+// own type strings (SamplerTypeConst, SamplerTypeProbabilistic, SamplerTypeRateLimiting) and
+// the generic ones shared with the OTel backend (SamplerTypeRatio, SamplerTypeAlwaysOn,
+// SamplerTypeAlwaysOff) so a PerOperation override doesn't silently misbehave depending on
+// which constant the caller reached for.
+func newJaegerSampler(cfg SamplerConfig) (jaegerclient.Sampler, error) {
+	switch cfg.Type {
+	case SamplerTypeProbabilistic, SamplerTypeRatio:
+		return jaegerclient.NewProbabilisticSampler(cfg.Param)
+	case SamplerTypeRateLimiting:
+		return jaegerclient.NewRateLimitingSampler(cfg.Param), nil
+	case SamplerTypeAlwaysOn:
+		return jaegerclient.NewConstSampler(true), nil
+	case SamplerTypeAlwaysOff:
+		return jaegerclient.NewConstSampler(false), nil
+	case SamplerTypeConst, "":
+		return jaegerclient.NewConstSampler(cfg.Param != 0), nil
+	default:
+		return nil, fmt.Errorf("tracer: unknown jaeger sampler type %q", cfg.Type)
+	}
+}
+
+// perOperationSampler picks a sampler per operation name (e.g. drop "/healthz" and
+// "/metrics", sample DB spans at 1%) falling back to defaultSampler otherwise. This only
+// covers static, client-side overrides; use SamplerTypeRemote instead for strategies fetched
+// from the Jaeger agent/collector. samplers is built once by buildPerOperationSamplers, not
+// reconstructed per IsSampled call, so a SamplerTypeRateLimiting override keeps a single
+// token bucket across the whole process instead of resetting its limit on every span.
+type perOperationSampler struct {
+	defaultSampler jaegerclient.Sampler
+	samplers       map[string]jaegerclient.Sampler
+}
+
+// buildPerOperationSamplers constructs the concrete jaeger.Sampler for every PerOperation
+// override up front, so perOperationSampler.IsSampled only ever looks one up instead of
+// building (and, for SamplerTypeRateLimiting, resetting) a fresh sampler on every span.
+func buildPerOperationSamplers(overrides map[string]SamplerConfig) map[string]jaegerclient.Sampler {
+	samplers := make(map[string]jaegerclient.Sampler, len(overrides))
+	for operation, override := range overrides {
+		sampler, err := newJaegerSampler(override)
+		if err != nil {
+			log.Printf("ERROR: cannot init opentracing sampler for operation %q: %v\n", operation, err)
+			continue
+		}
+		samplers[operation] = sampler
+	}
+	return samplers
+}
+
+func (s *perOperationSampler) IsSampled(id jaegerclient.TraceID, operation string) (bool, []jaegerclient.Tag) {
+	if sampler, ok := s.samplers[operation]; ok {
+		return sampler.IsSampled(id, operation)
+	}
+	return s.defaultSampler.IsSampled(id, operation)
+}
+
+func (s *perOperationSampler) Close() {
+	s.defaultSampler.Close()
+	for _, sampler := range s.samplers {
+		sampler.Close()
+	}
+}
+
+func (s *perOperationSampler) Equal(other jaegerclient.Sampler) bool {
+	return false
+}
+
+// platformJaeger is the registry name for the OpenTracing/Jaeger backend, used with
+// SetTracerPlatformTypeByName to switch backends by config/env instead of by import.
+const platformJaeger = "jaeger"
+
 type jaegerPlatform struct{}
 
 func (j *jaegerPlatform) StartSpan(ctx context.Context, operationName string) Tracer {
@@ -122,8 +239,11 @@ func (j *jaegerPlatform) GetTraceID(ctx context.Context) string {
 
 	return traceID
 }
-func (j *jaegerPlatform) GetTraceURL(ctx context.Context) (u string) {
-	traceID := j.GetTraceID(ctx)
+func (j *jaegerPlatform) GetTraceURL(ctx context.Context) string {
+	return j.BuildTraceURL(j.GetTraceID(ctx))
+}
+
+func (j *jaegerPlatform) BuildTraceURL(traceID string) (u string) {
 	if traceID == "" {
 		return
 	}
@@ -189,6 +309,10 @@ func (t *jaegerTraceImpl) SetError(err error) {
 	ext.Error.Set(t.span, true)
 	t.span.SetTag("error.message", err.Error())
 
+	// tail-sample: force this span (and its trace) to be reported even if the head sampler
+	// had already decided to drop it, so error traces are never missing from Jaeger
+	ext.SamplingPriority.Set(t.span, 1)
+
 	stackTrace := make([]byte, 1024)
 	for {
 		n := runtime.Stack(stackTrace, false)
@@ -232,11 +356,14 @@ func (t *jaegerTraceImpl) Finish(opts ...FinishOptionFunc) {
 	}
 
 	t.SetError(finishOpt.Error)
+	if spanCtx, ok := t.span.Context().(jaegerclient.SpanContext); ok {
+		t.span.SetTag("sampled", spanCtx.IsSampled())
+	}
 	t.span.Finish()
 }
 
-// Log trace
-func Log(ctx context.Context, key string, value interface{}) {
+// Log implements TracerPlatformType.
+func (j *jaegerPlatform) Log(ctx context.Context, key string, value interface{}) {
 	span := opentracing.SpanFromContext(ctx)
 	if span == nil {
 		return
@@ -245,8 +372,8 @@ func Log(ctx context.Context, key string, value interface{}) {
 	span.LogKV(key, toValue(value))
 }
 
-// LogEvent trace
-func LogEvent(ctx context.Context, event string, payload ...interface{}) {
+// LogEvent implements TracerPlatformType.
+func (j *jaegerPlatform) LogEvent(ctx context.Context, event string, payload ...interface{}) {
 	span := opentracing.SpanFromContext(ctx)
 	if span == nil {
 		return
@@ -0,0 +1,106 @@
+package tracer
+
+const (
+	// SamplerTypeConst always makes the same sampling decision for every trace, controlled by Param (0 or 1)
+	SamplerTypeConst = "const"
+	// SamplerTypeAlwaysOn samples every trace
+	SamplerTypeAlwaysOn = "always-on"
+	// SamplerTypeAlwaysOff samples no trace
+	SamplerTypeAlwaysOff = "always-off"
+	// SamplerTypeRatio samples a fraction of traces, controlled by Param (0.0-1.0)
+	SamplerTypeRatio = "ratio"
+	// SamplerTypeProbabilistic samples traces with probability Param (0.0-1.0), Jaeger only
+	SamplerTypeProbabilistic = "probabilistic"
+	// SamplerTypeRateLimiting samples at most Param traces per second, Jaeger only
+	SamplerTypeRateLimiting = "ratelimiting"
+	// SamplerTypeRemote fetches sampling strategies from the Jaeger agent and refreshes them periodically
+	SamplerTypeRemote = "remote"
+)
+
+type (
+	// SamplerConfig configures head-based sampling for a tracer backend
+	SamplerConfig struct {
+		// Type is one of SamplerTypeConst, SamplerTypeAlwaysOn, SamplerTypeAlwaysOff, SamplerTypeRatio,
+		// or (InitOpenTracing only) SamplerTypeProbabilistic, SamplerTypeRateLimiting, SamplerTypeRemote
+		Type string
+		// Param meaning depends on Type (0/1 for const, sampling fraction for ratio/probabilistic,
+		// traces/sec for ratelimiting)
+		Param float64
+		// SamplingServerURL is the Jaeger agent/collector URL polled for strategies when Type is SamplerTypeRemote
+		SamplingServerURL string
+		// PerOperation overrides Type/Param for specific operation names, e.g. to drop health check
+		// spans or sample database spans at a lower rate than the default. Applied by InitOpenTracing
+		// only, and not when Type is SamplerTypeRemote; InitOpenTelemetry ignores it
+		PerOperation map[string]SamplerConfig
+	}
+
+	// Option for init tracer platform
+	Option struct {
+		AgentHost       string
+		Level           string
+		BuildNumberTag  string
+		MaxGoroutineTag int
+		Sampler         SamplerConfig
+	}
+
+	// OptionFunc type
+	OptionFunc func(*Option)
+
+	// FinishOption must parsed in tracer.Finish()
+	FinishOption struct {
+		Tags  map[string]interface{}
+		Error error
+	}
+
+	// FinishOptionFunc type
+	FinishOptionFunc func(*FinishOption)
+)
+
+// SetAgentHost option func
+func SetAgentHost(host string) OptionFunc {
+	return func(o *Option) {
+		o.AgentHost = host
+	}
+}
+
+// SetLevel option func
+func SetLevel(level string) OptionFunc {
+	return func(o *Option) {
+		o.Level = level
+	}
+}
+
+// SetBuildNumberTag option func
+func SetBuildNumberTag(buildNumber string) OptionFunc {
+	return func(o *Option) {
+		o.BuildNumberTag = buildNumber
+	}
+}
+
+// SetMaxGoroutineTag option func
+func SetMaxGoroutineTag(max int) OptionFunc {
+	return func(o *Option) {
+		o.MaxGoroutineTag = max
+	}
+}
+
+// SetSampler option func, configure head-based sampling strategy for the tracer backend
+func SetSampler(sampler SamplerConfig) OptionFunc {
+	return func(o *Option) {
+		o.Sampler = sampler
+	}
+}
+
+// SetTagsFinishOption set additional tags when finishing a span
+func SetTagsFinishOption(tags map[string]interface{}) FinishOptionFunc {
+	return func(o *FinishOption) {
+		o.Tags = tags
+	}
+}
+
+// SetErrorFinishOption set error when finishing a span
+func SetErrorFinishOption(err error) FinishOptionFunc {
+	return func(o *FinishOption) {
+		o.Error = err
+	}
+}
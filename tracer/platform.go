@@ -0,0 +1,168 @@
+package tracer
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// TracerPlatformType is implemented by every pluggable tracer backend (jaeger, opentelemetry, ...)
+// so it can be registered and swapped via SetTracerPlatformType without touching call sites.
+type TracerPlatformType interface {
+	StartSpan(ctx context.Context, operationName string) Tracer
+	StartRootSpan(ctx context.Context, operationName string, header map[string]string) Tracer
+	GetTraceID(ctx context.Context) string
+	GetTraceURL(ctx context.Context) string
+	// BuildTraceURL builds the dashboard url for a trace id already known (e.g. from
+	// DecodeTraceID), without needing a live span in context.
+	BuildTraceURL(traceID string) string
+	// Log records a single key/value onto the span active in ctx, a no-op if ctx carries none.
+	Log(ctx context.Context, key string, value interface{})
+	// LogEvent records a named event, with optional payload, onto the span active in ctx, a
+	// no-op if ctx carries none.
+	LogEvent(ctx context.Context, event string, payload ...interface{})
+}
+
+var (
+	mu               sync.RWMutex
+	activePlatform   TracerPlatformType
+	platformRegistry = make(map[string]TracerPlatformType)
+)
+
+// RegisterTracerPlatformType registers a tracer backend under a name so it can be activated
+// later by name (e.g. from config/env) without importing and constructing it directly.
+func RegisterTracerPlatformType(name string, platform TracerPlatformType) {
+	mu.Lock()
+	defer mu.Unlock()
+	platformRegistry[name] = platform
+}
+
+// SetTracerPlatformType sets the active tracer backend used by the package-level StartSpan,
+// StartRootSpan, GetTraceID and GetTraceURL helpers. InitOpenTracing and InitOpenTelemetry
+// call this automatically after a successful setup.
+func SetTracerPlatformType(platform TracerPlatformType) {
+	mu.Lock()
+	defer mu.Unlock()
+	activePlatform = platform
+}
+
+// SetTracerPlatformTypeByName activates a tracer backend previously registered with
+// RegisterTracerPlatformType.
+func SetTracerPlatformTypeByName(name string) error {
+	mu.RLock()
+	platform, ok := platformRegistry[name]
+	mu.RUnlock()
+	if !ok {
+		return fmt.Errorf("tracer: platform %q is not registered", name)
+	}
+	SetTracerPlatformType(platform)
+	return nil
+}
+
+func getActivePlatform() TracerPlatformType {
+	mu.RLock()
+	defer mu.RUnlock()
+	return activePlatform
+}
+
+// StartSpan start a child span from the parent span stored in ctx, using the active tracer platform
+func StartSpan(ctx context.Context, operationName string) Tracer {
+	platform := getActivePlatform()
+	if platform == nil {
+		return &noopTrace{ctx: ctx}
+	}
+	return platform.StartSpan(ctx, operationName)
+}
+
+// StartRootSpan start a new root span, extracting the parent span context from header when present
+func StartRootSpan(ctx context.Context, operationName string, header map[string]string) Tracer {
+	platform := getActivePlatform()
+	if platform == nil {
+		return &noopTrace{ctx: ctx}
+	}
+	return platform.StartRootSpan(ctx, operationName, header)
+}
+
+// GetTraceID get the trace id carried by ctx, using the active tracer platform
+func GetTraceID(ctx context.Context) string {
+	platform := getActivePlatform()
+	if platform == nil {
+		return ""
+	}
+	return platform.GetTraceID(ctx)
+}
+
+// GetTraceURL get the trace dashboard url for the trace carried by ctx, using the active tracer platform
+func GetTraceURL(ctx context.Context) string {
+	platform := getActivePlatform()
+	if platform == nil {
+		return ""
+	}
+	return platform.GetTraceURL(ctx)
+}
+
+// BuildTraceURL builds the trace dashboard url for a trace id already known (e.g. from
+// DecodeTraceID), using the active tracer platform. Unlike GetTraceURL this needs no span in
+// context, so callers that only have a stored TraceHeader (not a live ctx) can still link to
+// the trace without starting and reporting a throwaway span just to read an id back out.
+func BuildTraceURL(traceID string) string {
+	platform := getActivePlatform()
+	if platform == nil || traceID == "" {
+		return ""
+	}
+	return platform.BuildTraceURL(traceID)
+}
+
+// DecodeTraceID extracts the trace id from a header previously populated by
+// Tracer.InjectRequestHeader, without starting or reporting a span. Supports both carrier
+// formats the bundled backends use: OpenTelemetry/W3C's "traceparent"
+// (00-<trace-id>-<span-id>-<flags>) and Jaeger's native "uber-trace-id"
+// (<trace-id>:<span-id>:<parent-id>:<flags>).
+func DecodeTraceID(header map[string]string) string {
+	if tp := header["traceparent"]; tp != "" {
+		if parts := strings.Split(tp, "-"); len(parts) >= 2 && parts[1] != "" {
+			return parts[1]
+		}
+	}
+	if ut := header["uber-trace-id"]; ut != "" {
+		if parts := strings.Split(ut, ":"); len(parts) >= 1 && parts[0] != "" {
+			return parts[0]
+		}
+	}
+	return ""
+}
+
+// Log records a single key/value onto the span active in ctx, using the active tracer
+// platform. A no-op until a tracer platform has been initialized.
+func Log(ctx context.Context, key string, value interface{}) {
+	platform := getActivePlatform()
+	if platform == nil {
+		return
+	}
+	platform.Log(ctx, key, value)
+}
+
+// LogEvent records a named event, with optional payload, onto the span active in ctx, using
+// the active tracer platform. A no-op until a tracer platform has been initialized.
+func LogEvent(ctx context.Context, event string, payload ...interface{}) {
+	platform := getActivePlatform()
+	if platform == nil {
+		return
+	}
+	platform.LogEvent(ctx, event, payload...)
+}
+
+// noopTrace is returned when no tracer platform has been initialized, so instrumentation
+// code can call tracer.StartSpan unconditionally without nil-checking the result.
+type noopTrace struct {
+	ctx context.Context
+}
+
+func (n *noopTrace) Context() context.Context                     { return n.ctx }
+func (n *noopTrace) Tags() map[string]interface{}                 { return map[string]interface{}{} }
+func (n *noopTrace) SetTag(key string, value interface{})         {}
+func (n *noopTrace) InjectRequestHeader(header map[string]string) {}
+func (n *noopTrace) SetError(err error)                           {}
+func (n *noopTrace) Log(key string, value interface{})            {}
+func (n *noopTrace) Finish(opts ...FinishOptionFunc)              {}